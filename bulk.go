@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/skip2/go-qrcode"
+)
+
+// readBulkLines はテキスト/CSVファイルを読み込み、各行を (ファイル名, テキスト) のペアに変換します。
+// useFilenameColumn が true の場合、各行をCSVとして解釈し1列目をファイル名、残りをテキストとして扱います。
+// false の場合、行全体をテキストとし、ファイル名は連番で自動生成します。
+func readBulkLines(path string, useFilenameColumn bool) ([][2]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("入力ファイルを開けませんでした (%s): %w", path, err)
+	}
+	defer file.Close()
+
+	var rows [][2]string
+	if useFilenameColumn {
+		r := csv.NewReader(file)
+		r.FieldsPerRecord = -1
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("CSVの解析に失敗しました: %w", err)
+		}
+		for _, rec := range records {
+			if len(rec) == 0 || strings.TrimSpace(rec[0]) == "" {
+				continue
+			}
+			name := strings.TrimSpace(rec[0])
+			text := strings.TrimSpace(strings.Join(rec[1:], ","))
+			if text == "" {
+				text = name // ファイル名列しかない行はそれ自体をテキストとして扱う
+			}
+			rows = append(rows, [2]string{name, text})
+		}
+		return rows, nil
+	}
+
+	scanner := bufio.NewScanner(file)
+	i := 1
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		rows = append(rows, [2]string{fmt.Sprintf("qrcode_%03d", i), line})
+		i++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("入力ファイルの読み込みに失敗しました: %w", err)
+	}
+	return rows, nil
+}
+
+// sanitizeBulkFilename はCSVの1列目から来たファイル名候補を、出力先ディレクトリ外への書き込みを
+// 防ぐために正規化します (パス区切りや親ディレクトリ参照を取り除く)。
+func sanitizeBulkFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	if name == "" || name == "." || name == ".." {
+		name = "qrcode"
+	}
+	return name
+}
+
+// ShowBulkGenerateWindow はテキスト/CSVファイルから複数のQRコードPNGを一括生成するウィンドウを表示します。
+func ShowBulkGenerateWindow(a fyne.App, parent fyne.Window) {
+	win := a.NewWindow("一括生成")
+	win.Resize(fyne.NewSize(420, 360))
+
+	inputPathLabel := widget.NewLabel("入力ファイル: (未選択)")
+	outputDirLabel := widget.NewLabel("出力先フォルダ: (未選択)")
+	var inputPath, outputDir string
+
+	chooseInputBtn := widget.NewButton("入力ファイルを選択", func() {
+		d := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil || r == nil {
+				return
+			}
+			defer r.Close()
+			inputPath = r.URI().Path()
+			inputPathLabel.SetText("入力ファイル: " + filepath.Base(inputPath))
+		}, win)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".txt", ".csv"}))
+		d.Show()
+	})
+
+	chooseOutputBtn := widget.NewButton("出力先フォルダを選択", func() {
+		d := dialog.NewFolderOpen(func(u fyne.ListableURI, err error) {
+			if err != nil || u == nil {
+				return
+			}
+			outputDir = u.Path()
+			outputDirLabel.SetText("出力先フォルダ: " + outputDir)
+		}, win)
+		d.Show()
+	})
+
+	useFilenameCheck := widget.NewCheck("1列目をファイル名として使う (CSV)", nil)
+
+	levelSelect := widget.NewSelect([]string{"Low", "Medium", "High", "Highest"}, nil)
+	levelSelect.SetSelected("Medium")
+
+	sizeEntry := widget.NewEntry()
+	sizeEntry.SetText("256")
+
+	progress := widget.NewProgressBar()
+	statusLabel := widget.NewLabel("")
+
+	startBtn := widget.NewButton("一括生成を開始", nil)
+	startBtn.OnTapped = func() {
+		if inputPath == "" || outputDir == "" {
+			dialog.ShowInformation("情報", "入力ファイルと出力先フォルダを選択してください。", win)
+			return
+		}
+		size, err := strconv.Atoi(sizeEntry.Text)
+		if err != nil || size <= 0 {
+			dialog.ShowError(fmt.Errorf("ピクセルサイズには正の整数を入力してください。"), win)
+			return
+		}
+		level := recoveryLevelFromLabel(levelSelect.Selected)
+
+		rows, err := readBulkLines(inputPath, useFilenameCheck.Checked)
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if len(rows) == 0 {
+			dialog.ShowInformation("情報", "入力ファイルに有効な行がありませんでした。", win)
+			return
+		}
+
+		startBtn.Disable()
+
+		// UIをフリーズさせずプログレスバーを随時更新できるよう、生成処理は別goroutineで実行する
+		go func() {
+			defer startBtn.Enable()
+
+			used := make(map[string]bool) // 実際に書き出したファイル名の集合 (サフィックス付与後の衝突も再チェックする)
+			failed := 0
+			for i, row := range rows {
+				name, text := row[0], row[1]
+				base := sanitizeBulkFilename(name)
+				outName := base
+				for n := 1; used[outName]; n++ {
+					outName = fmt.Sprintf("%s_%d", base, n)
+				}
+				used[outName] = true
+
+				img, err := GenerateQR(text, level, size)
+				if err == nil {
+					outPath := filepath.Join(outputDir, outName+".png")
+					f, ferr := os.Create(outPath)
+					if ferr == nil {
+						err = png.Encode(f, img)
+						f.Close()
+					} else {
+						err = ferr
+					}
+				}
+				if err != nil {
+					failed++
+				}
+				progress.SetValue(float64(i+1) / float64(len(rows)))
+				statusLabel.SetText(fmt.Sprintf("%d / %d 件処理済み (失敗 %d 件)", i+1, len(rows), failed))
+			}
+			dialog.ShowInformation("完了", fmt.Sprintf("%d 件中 %d 件のQRコードを生成しました。", len(rows), len(rows)-failed), win)
+		}()
+	}
+
+	content := container.NewVBox(
+		chooseInputBtn, inputPathLabel,
+		chooseOutputBtn, outputDirLabel,
+		useFilenameCheck,
+		widget.NewLabel("誤り訂正レベル:"), levelSelect,
+		widget.NewLabel("ピクセルサイズ:"), sizeEntry,
+		startBtn,
+		progress,
+		statusLabel,
+	)
+	win.SetContent(content)
+	win.Show()
+}
+
+// recoveryLevelFromLabel はUI上の表示ラベルを go-qrcode の RecoveryLevel に変換します。
+func recoveryLevelFromLabel(label string) qrcode.RecoveryLevel {
+	switch label {
+	case "Low":
+		return qrcode.Low
+	case "High":
+		return qrcode.High
+	case "Highest":
+		return qrcode.Highest
+	default:
+		return qrcode.Medium
+	}
+}
@@ -0,0 +1,36 @@
+//go:build darwin
+
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// copyImageToClipboard は macOS で osascript 経由で画像をクリップボードにコピーします。
+func copyImageToClipboard(img image.Image) error {
+	tmpPath := filepath.Join(os.TempDir(), fmt.Sprintf("qrcode_%d_%d.png", os.Getpid(), time.Now().UnixNano()))
+
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("一時ファイル作成失敗 (%s): %w", tmpPath, err)
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return fmt.Errorf("PNGエンコード失敗: %w", err)
+	}
+	file.Close()
+	defer os.Remove(tmpPath)
+
+	script := fmt.Sprintf(`set the clipboard to (read (POSIX file "%s") as {«class PNGf»})`, tmpPath)
+	cmd := exec.Command("osascript", "-e", script)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("osascript実行失敗: %w\n出力: %s", err, string(out))
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+//go:build linux
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+)
+
+// copyImageToClipboard は Linux で Wayland (wl-copy) または X11 (xclip) を検出して画像をコピーします。
+func copyImageToClipboard(img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return fmt.Errorf("PNGエンコード失敗: %w", err)
+	}
+
+	var cmd *exec.Cmd
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if _, err := exec.LookPath("wl-copy"); err == nil {
+			cmd = exec.Command("wl-copy", "--type", "image/png")
+		}
+	}
+	if cmd == nil {
+		if _, err := exec.LookPath("xclip"); err == nil {
+			cmd = exec.Command("xclip", "-selection", "clipboard", "-t", "image/png", "-i")
+		} else {
+			return fmt.Errorf("wl-copy も xclip も見つかりませんでした。どちらかをインストールしてください")
+		}
+	}
+
+	cmd.Stdin = &buf
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s実行失敗: %w\n出力: %s", cmd.Path, err, string(out))
+	}
+	return nil
+}
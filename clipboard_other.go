@@ -0,0 +1,14 @@
+//go:build !windows && !darwin && !linux
+
+package main
+
+import (
+	"fmt"
+	"image"
+	"runtime"
+)
+
+// copyImageToClipboard は windows/darwin/linux 以外の未対応OS向けのフォールバックです。
+func copyImageToClipboard(img image.Image) error {
+	return fmt.Errorf("クリップボードへの画像コピーはこのOSでは現在サポートされていません (%s)", runtime.GOOS)
+}
@@ -0,0 +1,108 @@
+//go:build windows
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	user32   = syscall.NewLazyDLL("user32.dll")
+	kernel32 = syscall.NewLazyDLL("kernel32.dll")
+
+	procOpenClipboard    = user32.NewProc("OpenClipboard")
+	procEmptyClipboard   = user32.NewProc("EmptyClipboard")
+	procSetClipboardData = user32.NewProc("SetClipboardData")
+	procCloseClipboard   = user32.NewProc("CloseClipboard")
+	procGlobalAlloc      = kernel32.NewProc("GlobalAlloc")
+	procGlobalLock       = kernel32.NewProc("GlobalLock")
+	procGlobalUnlock     = kernel32.NewProc("GlobalUnlock")
+)
+
+const (
+	cfDIB        = 8
+	gmemMoveable = 0x0002
+)
+
+// bitmapInfoHeader は Win32 の BITMAPINFOHEADER 構造体のレイアウトです。
+type bitmapInfoHeader struct {
+	Size          uint32
+	Width         int32
+	Height        int32
+	Planes        uint16
+	BitCount      uint16
+	Compression   uint32
+	SizeImage     uint32
+	XPelsPerMeter int32
+	YPelsPerMeter int32
+	ClrUsed       uint32
+	ClrImportant  uint32
+}
+
+// imageToDIB は image.Image を 32bit トップダウンDIB (BITMAPINFOHEADER + ピクセルデータ) に変換します。
+func imageToDIB(img image.Image) []byte {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, img, bounds.Min, draw.Src)
+
+	header := bitmapInfoHeader{
+		Size:     40,
+		Width:    int32(w),
+		Height:   -int32(h), // 負の高さ = トップダウンDIB (PowerShellのBitmap生成とは異なり上下反転が不要)
+		Planes:   1,
+		BitCount: 32,
+	}
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, header)
+
+	row := make([]byte, w*4)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := rgba.RGBAAt(x, y)
+			i := x * 4
+			row[i], row[i+1], row[i+2], row[i+3] = c.B, c.G, c.R, c.A
+		}
+		buf.Write(row)
+	}
+	return buf.Bytes()
+}
+
+// copyImageToClipboard は Win32 API (SetClipboardData with CF_DIB) を直接呼び出して画像をコピーします。
+// 一時ファイル経由の PowerShell 呼び出しよりも高速で、プロセス起動のオーバーヘッドがありません。
+func copyImageToClipboard(img image.Image) error {
+	dib := imageToDIB(img)
+
+	if r, _, _ := procOpenClipboard.Call(0); r == 0 {
+		return fmt.Errorf("OpenClipboardに失敗しました")
+	}
+	defer procCloseClipboard.Call()
+
+	procEmptyClipboard.Call()
+
+	hMem, _, _ := procGlobalAlloc.Call(gmemMoveable, uintptr(len(dib)))
+	if hMem == 0 {
+		return fmt.Errorf("GlobalAllocに失敗しました")
+	}
+
+	ptr, _, _ := procGlobalLock.Call(hMem)
+	if ptr == 0 {
+		return fmt.Errorf("GlobalLockに失敗しました")
+	}
+	dst := (*[1 << 30]byte)(unsafe.Pointer(ptr))[:len(dib):len(dib)]
+	copy(dst, dib)
+	procGlobalUnlock.Call(hMem)
+
+	if r, _, _ := procSetClipboardData.Call(cfDIB, hMem); r == 0 {
+		return fmt.Errorf("SetClipboardDataに失敗しました")
+	}
+	return nil
+}
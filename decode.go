@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg" // JPEG デコード用 (image.Decode の登録)
+	_ "image/png"  // PNG デコード用 (image.Decode の登録)
+	"os"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/multi"
+	"github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// DecodeQRCodesFromFile は指定されたパスの画像ファイル(PNG/JPEG)を読み込み、
+// 含まれるすべてのQRコードをデコードして、読み取れたテキストのスライスを返します。
+// 画像内に複数のQRコードが含まれる場合でもすべて検出します。
+func DecodeQRCodesFromFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("画像ファイルを開けませんでした (%s): %w", path, err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("画像のデコードに失敗しました (%s): %w", path, err)
+	}
+	return DecodeQRCodesFromImage(img)
+}
+
+// DecodeQRCodesFromImage はメモリ上の image.Image からQRコードをすべてデコードします。
+// ロゴ埋め込み後の画像が依然としてスキャン可能かを確認する用途などに使います。
+func DecodeQRCodesFromImage(img image.Image) ([]string, error) {
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return nil, fmt.Errorf("画像のビットマップ化に失敗しました: %w", err)
+	}
+
+	reader := multi.NewGenericMultipleBarcodeReader(qrcode.NewQRCodeReader())
+	results, err := reader.DecodeMultiple(bitmap, nil)
+	if err != nil {
+		return nil, fmt.Errorf("QRコードが見つかりませんでした: %w", err)
+	}
+
+	texts := make([]string, 0, len(results))
+	for _, r := range results {
+		texts = append(texts, r.GetText())
+	}
+	return texts, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"os"
+
+	"github.com/nfnt/resize"
+	"github.com/skip2/go-qrcode"
+)
+
+// EmbedLogo はQRコード画像の中央にロゴ画像を合成します。ロゴはQRコード幅の約20%にリサイズされます。
+func EmbedLogo(qrImg image.Image, logo image.Image) image.Image {
+	qrBounds := qrImg.Bounds()
+	qrSize := qrBounds.Dx()
+
+	logoSize := uint(float64(qrSize) * 0.2)
+	resizedLogo := resize.Resize(logoSize, logoSize, logo, resize.Lanczos3)
+
+	canvas := image.NewRGBA(qrBounds)
+	draw.Draw(canvas, qrBounds, qrImg, image.Point{}, draw.Src)
+
+	logoBounds := resizedLogo.Bounds()
+	offsetX := qrBounds.Min.X + (qrSize-logoBounds.Dx())/2
+	offsetY := qrBounds.Min.Y + (qrSize-logoBounds.Dy())/2
+	destRect := image.Rect(offsetX, offsetY, offsetX+logoBounds.Dx(), offsetY+logoBounds.Dy())
+	draw.Draw(canvas, destRect, resizedLogo, image.Point{}, draw.Over)
+
+	return canvas
+}
+
+// GenerateQRWithLogo は誤り訂正レベルをHighestに固定してQRコードを生成し、logoPath の画像を中央に埋め込みます。
+// 埋め込み後もデコード可能かをラウンドトリップで検証し、読み取れない場合はエラーを返します。
+func GenerateQRWithLogo(text string, size int, logoPath string) (image.Image, error) {
+	qr, err := qrcode.New(text, qrcode.Highest)
+	if err != nil {
+		return nil, fmt.Errorf("QRコードの生成に失敗しました: %w", err)
+	}
+	qrImg := qr.Image(size)
+
+	logoFile, err := os.Open(logoPath)
+	if err != nil {
+		return nil, fmt.Errorf("ロゴ画像を開けませんでした (%s): %w", logoPath, err)
+	}
+	defer logoFile.Close()
+
+	logoImg, _, err := image.Decode(logoFile)
+	if err != nil {
+		return nil, fmt.Errorf("ロゴ画像のデコードに失敗しました: %w", err)
+	}
+
+	composed := EmbedLogo(qrImg, logoImg)
+
+	if texts, err := DecodeQRCodesFromImage(composed); err != nil || len(texts) == 0 {
+		return nil, fmt.Errorf("ロゴ埋め込み後にQRコードを読み取れませんでした。別のロゴ画像を試してください: %w", err)
+	}
+
+	return composed, nil
+}
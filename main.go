@@ -1,15 +1,13 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
 	"image"
 	"image/png"
 	"log"
-	"os"
-	"os/exec"
 	"path/filepath" // filepath パッケージをインポート
-	"runtime"       // runtime パッケージをインポート (OS判定用)
+	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -22,92 +20,24 @@ import (
 	"github.com/skip2/go-qrcode" // QRコード生成ライブラリ
 )
 
-// --- クリップボード関連 ---
+// scanImageExtensions は画像読み取り(QRデコード)で受け付ける拡張子です。
+var scanImageExtensions = []string{".png", ".jpg", ".jpeg"}
 
-// copyImageToClipboardWindows は Windows 環境で画像をクリップボードにコピーします。
-func copyImageToClipboardWindows(img image.Image) error {
-	// 一時ディレクトリを取得
-	tmpDir := os.TempDir()
-	if tmpDir == "" {
-		return fmt.Errorf("一時ディレクトリが見つかりません")
-	}
-
-	// 一時ファイル名をユニークにする (プロセスIDとナノ秒タイムスタンプ)
-	tmpFileName := fmt.Sprintf("temp_qrcode_%d_%d.png", os.Getpid(), time.Now().UnixNano())
-	tmpFilePath := filepath.Join(tmpDir, tmpFileName)
-
-	// 一時ファイルを作成
-	file, err := os.Create(tmpFilePath)
-	if err != nil {
-		return fmt.Errorf("一時ファイル作成失敗 (%s): %w", tmpFilePath, err)
-	}
-
-	// この関数が終了する際に、ファイルを閉じてから削除する
-	defer func() {
-		// ファイルを閉じる (エラーはログに出力するだけにする)
-		if errClose := file.Close(); errClose != nil {
-			log.Printf("一時ファイルクローズエラー (%s): %v", tmpFilePath, errClose)
-		}
-		// 一時ファイルを削除 (存在しないエラーは無視)
-		if errRemove := os.Remove(tmpFilePath); errRemove != nil && !os.IsNotExist(errRemove) {
-			log.Printf("一時ファイル削除失敗 (%s): %v", tmpFilePath, errRemove)
-		} else if errRemove == nil {
-			log.Printf("一時ファイル削除成功 (%s)", tmpFilePath)
+// isScanImagePath は path の拡張子が scanImageExtensions に含まれるかを判定します。
+// ドラッグ&ドロップされたファイルを、ファイル選択ダイアログと同じ条件でフィルタするために使います。
+func isScanImagePath(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, allowed := range scanImageExtensions {
+		if ext == allowed {
+			return true
 		}
-	}()
-
-	// QRコード画像をPNG形式で一時ファイルにエンコード
-	err = png.Encode(file, img)
-	if err != nil {
-		return fmt.Errorf("PNGエンコード失敗: %w", err)
 	}
-
-	// ファイルへの書き込みを完了させるために一度閉じる（deferでも閉じるが、ここでエラーを確認）
-	if err := file.Close(); err != nil {
-		return fmt.Errorf("一時ファイルへの書き込み完了(クローズ)失敗 (%s): %w", tmpFilePath, err)
-	}
-
-	// --- PowerShell コマンドの準備 ---
-	psEscapedPath := filepath.ToSlash(tmpFilePath)
-	psCmd := fmt.Sprintf(`
-Add-Type -AssemblyName System.Windows.Forms;
-$ErrorActionPreference = 'Stop';
-try {
-    $img = [System.Drawing.Image]::FromFile('%s');
-    [System.Windows.Forms.Clipboard]::SetImage($img);
-    $img.Dispose();
-} catch {
-    Write-Error "クリップボードへの画像設定中にエラーが発生しました: $($_.Exception.Message)";
-    exit 1;
-}
-`, psEscapedPath)
-
-	// --- PowerShell コマンド実行 ---
-	cmd := exec.Command("powershell", "-Command", psCmd)
-	output, err := cmd.CombinedOutput() // 標準出力と標準エラーを結合して取得
-	log.Printf("実行したPowerShellコマンド: powershell -Command \"%s\"", psCmd)
-	log.Printf("PowerShellからの出力:\n%s", string(output))
-	if err != nil {
-		return fmt.Errorf("PowerShell実行失敗: %w\n出力: %s", err, string(output))
-	}
-	log.Println("PowerShellによるクリップボードへの画像コピー成功")
-	return nil
-}
-
-// copyImageToClipboardOther は Windows 以外の OS 用のプレースホルダー関数です。
-func copyImageToClipboardOther(img image.Image) error {
-	return fmt.Errorf("クリップボードへの画像コピーはこのOSでは現在サポートされていません (%s)", runtime.GOOS)
+	return false
 }
 
-// copyImageToClipboard は OS を判定し、適切なコピー関数を呼び出すラッパーです。
-func copyImageToClipboard(img image.Image) error {
-	switch runtime.GOOS {
-	case "windows":
-		return copyImageToClipboardWindows(img)
-	default:
-		return copyImageToClipboardOther(img)
-	}
-}
+// copyImageToClipboard は画像をOSのクリップボードにコピーします。
+// 実装はOSごとに分離されており (clipboard_windows.go, clipboard_darwin.go, clipboard_linux.go)、
+// このファイルでは共通のシグネチャだけを前提に呼び出します。
 
 // --- メイン処理 ---
 
@@ -130,6 +60,17 @@ func main() {
 	w.SetMaster()                    // このウィンドウが閉じられたらアプリを終了する
 	w.CenterOnScreen()               // ウィンドウを画面中央に表示
 
+	// --- メニュー ---
+	toolsMenu := fyne.NewMenu("ツール",
+		fyne.NewMenuItem("一括生成...", func() {
+			ShowBulkGenerateWindow(a, w)
+		}),
+		fyne.NewMenuItem("ポスター作成...", func() {
+			ShowPosterWindow(a, w)
+		}),
+	)
+	w.SetMainMenu(fyne.NewMainMenu(toolsMenu))
+
 	// --- UI コンポーネントと関連変数 ---
 
 	// 入力用テキストエリア
@@ -153,6 +94,33 @@ func main() {
 	// 生成された image.Image を保持する変数 (コピー/保存用)
 	var currentImage image.Image
 
+	// ロゴ埋め込み関連の状態 (ロゴを埋め込むチェックボックスと選択されたロゴ画像パス)
+	var logoPath string
+	embedLogoCheck := widget.NewCheck("ロゴを埋め込む", nil)
+
+	logoPathLabel := widget.NewLabel("ロゴ画像: (未選択)")
+	chooseLogoBtn := widget.NewButton("ロゴ画像を選択", nil)
+	chooseLogoBtn.Disable()
+	chooseLogoBtn.OnTapped = func() {
+		d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			defer reader.Close()
+			logoPath = reader.URI().Path()
+			logoPathLabel.SetText("ロゴ画像: " + filepath.Base(logoPath))
+		}, w)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".png", ".jpg", ".jpeg"}))
+		d.Show()
+	}
+	embedLogoCheck.OnChanged = func(checked bool) {
+		if checked {
+			chooseLogoBtn.Enable()
+		} else {
+			chooseLogoBtn.Disable()
+		}
+	}
+
 	// --- QRコード生成処理 (共通関数化) ---
 	// このクロージャは inputEntry, currentImage, qrImageCanvas, statusLabel, w をキャプチャする
 	generateQRCode := func() {
@@ -166,30 +134,30 @@ func main() {
 			return
 		}
 
-		// QRコード生成 (go-qrcode ライブラリ使用)
-		pngData, err := qrcode.Encode(inputText, qrcode.Medium, 256)
-		if err != nil {
-			log.Printf("QRコードのエンコードに失敗: %v", err)
-			statusLabel.SetText(fmt.Sprintf("エラー: QRコード生成失敗 (エンコード): %v", err))
-			currentImage = nil
-			qrImageCanvas.Image = nil
-			qrImageCanvas.Refresh()
-			dialog.ShowError(fmt.Errorf("QRコードの生成に失敗しました。\n入力内容を確認してください。\n詳細: %w", err), w)
-			return
+		var imgData image.Image
+		var err error
+		if embedLogoCheck.Checked {
+			if logoPath == "" {
+				statusLabel.SetText("エラー: ロゴ画像が選択されていません。")
+				dialog.ShowError(fmt.Errorf("ロゴを埋め込むには、先にロゴ画像を選択してください。"), w)
+				return
+			}
+			// ロゴ埋め込み時は誤り訂正レベルを自動的に Highest にする
+			imgData, err = GenerateQRWithLogo(inputText, 256, logoPath)
+		} else {
+			// QRコード生成 (共通関数 GenerateQR を使用)
+			imgData, err = GenerateQR(inputText, qrcode.Medium, 256)
 		}
-
-		// PNGデータを image.Image にデコード
-		imgData, format, err := image.Decode(bytes.NewReader(pngData))
 		if err != nil {
-			log.Printf("生成されたQRコード(PNG)のデコードに失敗: %v", err)
-			statusLabel.SetText(fmt.Sprintf("エラー: QRコード生成失敗 (デコード): %v", err))
+			log.Printf("QRコード生成に失敗: %v", err)
+			statusLabel.SetText(fmt.Sprintf("エラー: QRコード生成失敗: %v", err))
 			currentImage = nil
 			qrImageCanvas.Image = nil
 			qrImageCanvas.Refresh()
-			dialog.ShowError(fmt.Errorf("内部エラー: 生成された画像の処理に失敗しました。\n詳細: %w", err), w)
+			dialog.ShowError(fmt.Errorf("QRコードの生成に失敗しました。\n入力内容を確認してください。\n詳細: %w", err), w)
 			return
 		}
-		log.Printf("QRコード生成成功 (フォーマット: %s)", format)
+		log.Println("QRコード生成成功")
 
 		// 生成された画像を保持し、キャンバスに表示
 		currentImage = imgData
@@ -274,10 +242,206 @@ func main() {
 		saveDialog.Show()
 	})
 
+	// handleDecodedImage はQRデコード結果を入力欄へ反映するか、複数件あれば選択ダイアログを表示します。
+	handleDecodedImage := func(path string) {
+		texts, err := DecodeQRCodesFromFile(path)
+		if err != nil {
+			statusLabel.SetText("エラー: QRコードの読み取りに失敗しました。")
+			dialog.ShowError(fmt.Errorf("画像からQRコードを読み取れませんでした。\n詳細: %w", err), w)
+			return
+		}
+		if len(texts) == 1 {
+			inputEntry.SetText(texts[0])
+			statusLabel.SetText("画像からQRコードを読み取りました。")
+			return
+		}
+		// 複数のQRコードが見つかった場合は一覧から選ばせる
+		list := widget.NewList(
+			func() int { return len(texts) },
+			func() fyne.CanvasObject { return widget.NewLabel("") },
+			func(i widget.ListItemID, o fyne.CanvasObject) { o.(*widget.Label).SetText(texts[i]) },
+		)
+		listDialog := dialog.NewCustom("読み取り結果を選択", "閉じる", container.NewGridWrap(fyne.NewSize(380, 200), list), w)
+		list.OnSelected = func(i widget.ListItemID) {
+			inputEntry.SetText(texts[i])
+			statusLabel.SetText("画像からQRコードを読み取りました。")
+			listDialog.Hide()
+		}
+		listDialog.Show()
+	}
+
+	// 画像を読み取るボタン (ファイル選択 → QRデコード)
+	scanBtn := widget.NewButton("画像を読み取る", func() {
+		openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				statusLabel.SetText("エラー: 画像選択中にエラーが発生しました。")
+				dialog.ShowError(fmt.Errorf("画像選択エラー: %w", err), w)
+				return
+			}
+			if reader == nil {
+				return // キャンセル
+			}
+			defer reader.Close()
+			handleDecodedImage(reader.URI().Path())
+		}, w)
+		openDialog.SetFilter(storage.NewExtensionFileFilter(scanImageExtensions))
+		openDialog.Show()
+	})
+
+	// ウィンドウへのドラッグ＆ドロップでも画像からのQRコード読み取りを受け付ける。
+	// ファイル選択ダイアログ(scanBtn)と同様、拡張子でフィルタし、対象外ファイルのドロップでは何もしない。
+	// 複数ファイルがドロップされても、ダイアログが積み重ならないよう最初の1件のみ処理する。
+	w.SetOnDropped(func(_ fyne.Position, uris []fyne.URI) {
+		for _, u := range uris {
+			if isScanImagePath(u.Path()) {
+				handleDecodedImage(u.Path())
+				break
+			}
+		}
+	})
+
+	// コンテンツタイプ別の入力フォーム (vCard/Wi-Fi/mailto/geo/SMS/TOTP) を差し込むコンテナ。
+	// 選択中のタイプに応じて中身を入れ替え、各フォームの「生成」ボタンが inputEntry を組み立てて generateQRCode を呼ぶ。
+	typeFormContainer := container.NewVBox()
+
+	buildContentForm := func(contentType string) fyne.CanvasObject {
+		switch contentType {
+		case "vCard":
+			nameEntry := widget.NewEntry()
+			phoneEntry := widget.NewEntry()
+			emailEntry := widget.NewEntry()
+			orgEntry := widget.NewEntry()
+			genBtn := widget.NewButton("生成", func() {
+				inputEntry.SetText(BuildVCard(nameEntry.Text, phoneEntry.Text, emailEntry.Text, orgEntry.Text))
+				generateQRCode()
+			})
+			return widget.NewForm(
+				widget.NewFormItem("氏名", nameEntry),
+				widget.NewFormItem("電話番号", phoneEntry),
+				widget.NewFormItem("メール", emailEntry),
+				widget.NewFormItem("組織", orgEntry),
+				widget.NewFormItem("", genBtn),
+			)
+		case "Wi-Fi":
+			ssidEntry := widget.NewEntry()
+			passEntry := widget.NewEntry()
+			authSelect := widget.NewSelect([]string{"WPA", "WEP", "nopass"}, nil)
+			authSelect.SetSelected("WPA")
+			hiddenCheck := widget.NewCheck("非表示ネットワーク", nil)
+			genBtn := widget.NewButton("生成", func() {
+				inputEntry.SetText(BuildWiFi(ssidEntry.Text, passEntry.Text, authSelect.Selected, hiddenCheck.Checked))
+				generateQRCode()
+			})
+			return widget.NewForm(
+				widget.NewFormItem("SSID", ssidEntry),
+				widget.NewFormItem("パスワード", passEntry),
+				widget.NewFormItem("認証方式", authSelect),
+				widget.NewFormItem("", hiddenCheck),
+				widget.NewFormItem("", genBtn),
+			)
+		case "mailto":
+			addrEntry := widget.NewEntry()
+			subjectEntry := widget.NewEntry()
+			bodyEntry := widget.NewMultiLineEntry()
+			genBtn := widget.NewButton("生成", func() {
+				inputEntry.SetText(BuildMailto(addrEntry.Text, subjectEntry.Text, bodyEntry.Text))
+				generateQRCode()
+			})
+			return widget.NewForm(
+				widget.NewFormItem("宛先", addrEntry),
+				widget.NewFormItem("件名", subjectEntry),
+				widget.NewFormItem("本文", bodyEntry),
+				widget.NewFormItem("", genBtn),
+			)
+		case "geo":
+			latEntry := widget.NewEntry()
+			lonEntry := widget.NewEntry()
+			genBtn := widget.NewButton("生成", func() {
+				inputEntry.SetText(BuildGeo(latEntry.Text, lonEntry.Text))
+				generateQRCode()
+			})
+			return widget.NewForm(
+				widget.NewFormItem("緯度", latEntry),
+				widget.NewFormItem("経度", lonEntry),
+				widget.NewFormItem("", genBtn),
+			)
+		case "SMS":
+			numberEntry := widget.NewEntry()
+			messageEntry := widget.NewMultiLineEntry()
+			genBtn := widget.NewButton("生成", func() {
+				inputEntry.SetText(BuildSMS(numberEntry.Text, messageEntry.Text))
+				generateQRCode()
+			})
+			return widget.NewForm(
+				widget.NewFormItem("宛先番号", numberEntry),
+				widget.NewFormItem("本文", messageEntry),
+				widget.NewFormItem("", genBtn),
+			)
+		case "TOTP":
+			issuerEntry := widget.NewEntry()
+			issuerEntry.SetPlaceHolder("例: MyService")
+			labelEntry := widget.NewEntry()
+			labelEntry.SetPlaceHolder("例: user@example.com")
+			secretEntry := widget.NewEntry()
+			secretEntry.SetPlaceHolder("Base32シークレット")
+			genSecretBtn := widget.NewButton("ランダム生成", func() {
+				secret, err := GenerateRandomTOTPSecret()
+				if err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				secretEntry.SetText(secret)
+			})
+			digitsSelect := widget.NewSelect([]string{"6", "8"}, nil)
+			digitsSelect.SetSelected("6")
+			periodEntry := widget.NewEntry()
+			periodEntry.SetText("30")
+			genBtn := widget.NewButton("生成", func() {
+				if labelEntry.Text == "" || secretEntry.Text == "" {
+					dialog.ShowError(fmt.Errorf("アカウント名とシークレットを入力してください。"), w)
+					return
+				}
+				digits := 6
+				if digitsSelect.Selected == "8" {
+					digits = 8
+				}
+				period := 30
+				if p, err := strconv.Atoi(periodEntry.Text); err == nil && p > 0 {
+					period = p
+				}
+				inputEntry.SetText(BuildTOTPURI(issuerEntry.Text, labelEntry.Text, secretEntry.Text, digits, period))
+				generateQRCode()
+			})
+			return widget.NewForm(
+				widget.NewFormItem("発行者(issuer)", issuerEntry),
+				widget.NewFormItem("アカウント名", labelEntry),
+				widget.NewFormItem("シークレット", container.NewBorder(nil, nil, nil, genSecretBtn, secretEntry)),
+				widget.NewFormItem("桁数", digitsSelect),
+				widget.NewFormItem("周期(秒)", periodEntry),
+				widget.NewFormItem("", genBtn),
+			)
+		default:
+			return container.NewVBox() // プレーンテキスト: inputEntryを直接編集するのでフォームなし
+		}
+	}
+
+	contentTypeSelect := widget.NewSelect(
+		[]string{"プレーンテキスト", "vCard", "Wi-Fi", "mailto", "geo", "SMS", "TOTP"},
+		func(selected string) {
+			typeFormContainer.Objects = []fyne.CanvasObject{buildContentForm(selected)}
+			typeFormContainer.Refresh()
+		},
+	)
+	contentTypeSelect.SetSelected("プレーンテキスト")
+
 	// --- UI レイアウト ---
 
 	// 入力エリアと生成ボタンを縦に配置
-	inputArea := container.NewVBox(widget.NewLabel("入力テキスト:"), inputEntry, generateBtn)
+	logoArea := container.NewHBox(embedLogoCheck, chooseLogoBtn, logoPathLabel)
+	inputArea := container.NewVBox(
+		widget.NewLabel("コンテンツタイプ:"), contentTypeSelect, typeFormContainer,
+		widget.NewLabel("入力テキスト:"), inputEntry, logoArea, generateBtn, scanBtn,
+	)
 
 	// 画像表示エリア (中央寄せ)
 	imageArea := container.NewCenter(qrImageCanvas)
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/golang/freetype"
+	"github.com/golang/freetype/truetype"
+	"github.com/nfnt/resize"
+	"github.com/skip2/go-qrcode"
+)
+
+// PosterCaption はポスター上に描画する1つのキャプション(タイトル/サブタイトル/フッター等)を表します。
+type PosterCaption struct {
+	Text  string
+	X, Y  int
+	Size  float64
+	Color color.Color
+}
+
+// ComposePoster は背景画像の上に、指定位置・サイズのQRコードと各キャプションを合成します。
+// fontPath には freetype で読み込める TTF フォントファイルを指定します。
+func ComposePoster(bg image.Image, qrImg image.Image, qrRect image.Rectangle, fontPath string, captions []PosterCaption) (image.Image, error) {
+	bgBounds := bg.Bounds()
+	canvas := image.NewRGBA(bgBounds)
+	draw.Draw(canvas, bgBounds, bg, bgBounds.Min, draw.Src)
+
+	resizedQR := resize.Resize(uint(qrRect.Dx()), uint(qrRect.Dy()), qrImg, resize.Lanczos3)
+	draw.Draw(canvas, qrRect, resizedQR, image.Point{}, draw.Over)
+
+	if len(captions) == 0 {
+		return canvas, nil
+	}
+
+	fontBytes, err := os.ReadFile(fontPath)
+	if err != nil {
+		return nil, fmt.Errorf("フォントファイルを読み込めませんでした (%s): %w", fontPath, err)
+	}
+	font, err := truetype.Parse(fontBytes)
+	if err != nil {
+		return nil, fmt.Errorf("フォントの解析に失敗しました: %w", err)
+	}
+
+	ctx := freetype.NewContext()
+	ctx.SetDPI(72)
+	ctx.SetFont(font)
+	ctx.SetClip(canvas.Bounds())
+	ctx.SetDst(canvas)
+	ctx.SetHinting(0) // font.HintingNone
+
+	for _, c := range captions {
+		if strings.TrimSpace(c.Text) == "" {
+			continue
+		}
+		ctx.SetFontSize(c.Size)
+		ctx.SetSrc(image.NewUniform(c.Color))
+		pt := freetype.Pt(c.X, c.Y)
+		if _, err := ctx.DrawString(c.Text, pt); err != nil {
+			return nil, fmt.Errorf("キャプション %q の描画に失敗しました: %w", c.Text, err)
+		}
+	}
+
+	return canvas, nil
+}
+
+// parseHexColor は "#RRGGBB" 形式の文字列を color.Color に変換します。変換に失敗した場合は黒を返します。
+func parseHexColor(s string) color.Color {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) != 6 {
+		return color.Black
+	}
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(s, "%02x%02x%02x", &r, &g, &b); err != nil {
+		return color.Black
+	}
+	return color.RGBA{R: r, G: g, B: b, A: 255}
+}
+
+// posterCaptionRow はポスター編集ウィンドウ内の1キャプション分の入力フォームです。
+type posterCaptionRow struct {
+	label      string
+	textEntry  *widget.Entry
+	xEntry     *widget.Entry
+	yEntry     *widget.Entry
+	sizeEntry  *widget.Entry
+	colorEntry *widget.Entry
+}
+
+func newPosterCaptionRow(label, defaultY string) *posterCaptionRow {
+	r := &posterCaptionRow{
+		label:      label,
+		textEntry:  widget.NewEntry(),
+		xEntry:     widget.NewEntry(),
+		yEntry:     widget.NewEntry(),
+		sizeEntry:  widget.NewEntry(),
+		colorEntry: widget.NewEntry(),
+	}
+	r.textEntry.SetPlaceHolder(label)
+	r.xEntry.SetText("20")
+	r.yEntry.SetText(defaultY)
+	r.sizeEntry.SetText("32")
+	r.colorEntry.SetText("#000000")
+	return r
+}
+
+func (r *posterCaptionRow) toCaption() (PosterCaption, error) {
+	x, err := strconv.Atoi(r.xEntry.Text)
+	if err != nil {
+		return PosterCaption{}, fmt.Errorf("%s のX座標が不正です: %w", r.label, err)
+	}
+	y, err := strconv.Atoi(r.yEntry.Text)
+	if err != nil {
+		return PosterCaption{}, fmt.Errorf("%s のY座標が不正です: %w", r.label, err)
+	}
+	size, err := strconv.ParseFloat(r.sizeEntry.Text, 64)
+	if err != nil {
+		return PosterCaption{}, fmt.Errorf("%s のフォントサイズが不正です: %w", r.label, err)
+	}
+	return PosterCaption{
+		Text:  r.textEntry.Text,
+		X:     x,
+		Y:     y,
+		Size:  size,
+		Color: parseHexColor(r.colorEntry.Text),
+	}, nil
+}
+
+func (r *posterCaptionRow) formGroup() *fyne.Container {
+	return container.NewVBox(
+		widget.NewLabel(r.label+":"),
+		r.textEntry,
+		container.NewGridWithColumns(4,
+			widget.NewLabel("X"), r.xEntry, widget.NewLabel("Y"), r.yEntry,
+		),
+		container.NewGridWithColumns(4,
+			widget.NewLabel("サイズ"), r.sizeEntry, widget.NewLabel("色"), r.colorEntry,
+		),
+	)
+}
+
+// ShowPosterWindow はQRコードを背景画像に合成してポスターを作成するウィンドウを表示します。
+func ShowPosterWindow(a fyne.App, parent fyne.Window) {
+	win := a.NewWindow("ポスター作成")
+	win.Resize(fyne.NewSize(480, 640))
+
+	var bgPath, fontPath string
+	bgPathLabel := widget.NewLabel("背景画像: (未選択)")
+	fontPathLabel := widget.NewLabel("フォント(TTF): (未選択)")
+
+	chooseBgBtn := widget.NewButton("背景画像を選択", func() {
+		d := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil || r == nil {
+				return
+			}
+			defer r.Close()
+			bgPath = r.URI().Path()
+			bgPathLabel.SetText("背景画像: " + filepath.Base(bgPath))
+		}, win)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".png", ".jpg", ".jpeg"}))
+		d.Show()
+	})
+
+	chooseFontBtn := widget.NewButton("フォント(TTF)を選択", func() {
+		d := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil || r == nil {
+				return
+			}
+			defer r.Close()
+			fontPath = r.URI().Path()
+			fontPathLabel.SetText("フォント(TTF): " + filepath.Base(fontPath))
+		}, win)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".ttf"}))
+		d.Show()
+	})
+
+	qrTextEntry := widget.NewMultiLineEntry()
+	qrTextEntry.SetPlaceHolder("QRコードにするテキスト")
+	qrXEntry := widget.NewEntry()
+	qrXEntry.SetText("20")
+	qrYEntry := widget.NewEntry()
+	qrYEntry.SetText("20")
+	qrSizeEntry := widget.NewEntry()
+	qrSizeEntry.SetText("200")
+
+	titleRow := newPosterCaptionRow("タイトル", "260")
+	subtitleRow := newPosterCaptionRow("サブタイトル", "310")
+	footerRow := newPosterCaptionRow("フッター", "380")
+
+	statusLabel := widget.NewLabel("")
+
+	createBtn := widget.NewButton("ポスターを作成して保存", func() {
+		if bgPath == "" || fontPath == "" {
+			dialog.ShowInformation("情報", "背景画像とフォントを選択してください。", win)
+			return
+		}
+		if qrTextEntry.Text == "" {
+			dialog.ShowInformation("情報", "QRコードにするテキストを入力してください。", win)
+			return
+		}
+		qrX, errX := strconv.Atoi(qrXEntry.Text)
+		qrY, errY := strconv.Atoi(qrYEntry.Text)
+		qrSize, errS := strconv.Atoi(qrSizeEntry.Text)
+		if errX != nil || errY != nil || errS != nil || qrSize <= 0 {
+			dialog.ShowError(fmt.Errorf("QRコードの位置・サイズには整数を入力してください。"), win)
+			return
+		}
+
+		bgFile, err := os.Open(bgPath)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("背景画像を開けませんでした: %w", err), win)
+			return
+		}
+		bgImg, _, err := image.Decode(bgFile)
+		bgFile.Close()
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("背景画像のデコードに失敗しました: %w", err), win)
+			return
+		}
+
+		qrImg, err := GenerateQR(qrTextEntry.Text, qrcode.Medium, qrSize)
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+
+		captions := make([]PosterCaption, 0, 3)
+		for _, row := range []*posterCaptionRow{titleRow, subtitleRow, footerRow} {
+			c, err := row.toCaption()
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			captions = append(captions, c)
+		}
+
+		qrRect := image.Rect(qrX, qrY, qrX+qrSize, qrY+qrSize)
+		poster, err := ComposePoster(bgImg, qrImg, qrRect, fontPath, captions)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ポスターの合成に失敗しました: %w", err), win)
+			return
+		}
+
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			defer writer.Close()
+			path := writer.URI().Path()
+			var encErr error
+			if strings.HasSuffix(strings.ToLower(path), ".jpg") || strings.HasSuffix(strings.ToLower(path), ".jpeg") {
+				encErr = jpeg.Encode(writer, poster, &jpeg.Options{Quality: 90})
+			} else {
+				encErr = png.Encode(writer, poster)
+			}
+			if encErr != nil {
+				dialog.ShowError(fmt.Errorf("ポスターの保存に失敗しました: %w", encErr), win)
+				return
+			}
+			statusLabel.SetText("ポスターを保存しました: " + filepath.Base(path))
+		}, win)
+		saveDialog.SetFileName("poster_" + filepath.Base(bgPath))
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".png", ".jpg", ".jpeg"}))
+		saveDialog.Show()
+	})
+
+	content := container.NewVBox(
+		chooseBgBtn, bgPathLabel,
+		chooseFontBtn, fontPathLabel,
+		widget.NewLabel("QRコード内容:"), qrTextEntry,
+		container.NewGridWithColumns(6,
+			widget.NewLabel("X"), qrXEntry, widget.NewLabel("Y"), qrYEntry, widget.NewLabel("サイズ"), qrSizeEntry,
+		),
+		titleRow.formGroup(),
+		subtitleRow.formGroup(),
+		footerRow.formGroup(),
+		createBtn,
+		statusLabel,
+	)
+	win.SetContent(container.NewVScroll(content))
+	win.Show()
+}
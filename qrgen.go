@@ -0,0 +1,24 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+
+	"github.com/skip2/go-qrcode" // QRコード生成ライブラリ
+)
+
+// GenerateQR はテキストを指定の誤り訂正レベル・ピクセルサイズでQRコード画像に変換します。
+// 単体生成画面と一括生成画面の両方から共通で利用されます。
+func GenerateQR(text string, level qrcode.RecoveryLevel, size int) (image.Image, error) {
+	pngData, err := qrcode.Encode(text, level, size)
+	if err != nil {
+		return nil, fmt.Errorf("QRコードのエンコードに失敗しました: %w", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(pngData))
+	if err != nil {
+		return nil, fmt.Errorf("生成されたQRコード(PNG)のデコードに失敗しました: %w", err)
+	}
+	return img, nil
+}
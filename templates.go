@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// wifiQRSpecialChars は Wi-Fi QR フォーマットで特別な意味を持ち、バックスラッシュでエスケープが
+// 必要な文字です (https://github.com/zxing/zxing の Wi-Fi Network config 仕様に準拠)。
+var wifiQRSpecialChars = []string{`\`, `;`, `,`, `:`, `"`}
+
+// escapeWiFiField は BuildWiFi の S:/P: フィールドに埋め込む値をエスケープします。
+func escapeWiFiField(s string) string {
+	for _, c := range wifiQRSpecialChars {
+		s = strings.ReplaceAll(s, c, `\`+c)
+	}
+	return s
+}
+
+// vCardSpecialChars は vCard (RFC 2426) のテキスト値でバックスラッシュエスケープが必要な文字です。
+// `;` はコンポーネント区切り、`,` は値区切りとして解釈されるため、生の値に含まれていると壊れます。
+var vCardSpecialChars = []string{`\`, `;`, `,`}
+
+// escapeVCardField は vCard のプロパティ値に埋め込む文字列をエスケープします。
+// 改行は仕様通り `\n` (リテラルの `\` + `n`) に変換し、プロパティが途中で終端されるのを防ぎます。
+func escapeVCardField(s string) string {
+	for _, c := range vCardSpecialChars {
+		s = strings.ReplaceAll(s, c, `\`+c)
+	}
+	s = strings.ReplaceAll(s, "\r\n", `\n`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return s
+}
+
+// BuildVCard は vCard 3.0 形式のテキストを組み立てます。
+func BuildVCard(name, phone, email, org string) string {
+	name, phone, email, org = escapeVCardField(name), escapeVCardField(phone), escapeVCardField(email), escapeVCardField(org)
+
+	s := "BEGIN:VCARD\nVERSION:3.0\n"
+	s += fmt.Sprintf("N:%s\nFN:%s\n", name, name)
+	if org != "" {
+		s += fmt.Sprintf("ORG:%s\n", org)
+	}
+	if phone != "" {
+		s += fmt.Sprintf("TEL:%s\n", phone)
+	}
+	if email != "" {
+		s += fmt.Sprintf("EMAIL:%s\n", email)
+	}
+	s += "END:VCARD"
+	return s
+}
+
+// BuildWiFi は Wi-Fi 接続用のQRコード文字列を組み立てます (WIFI:T:<auth>;S:<ssid>;P:<pass>;H:<hidden>;;)。
+func BuildWiFi(ssid, password, auth string, hidden bool) string {
+	if auth == "" {
+		auth = "WPA"
+	}
+	return fmt.Sprintf("WIFI:T:%s;S:%s;P:%s;H:%t;;", auth, escapeWiFiField(ssid), escapeWiFiField(password), hidden)
+}
+
+// BuildMailto は件名・本文つきの mailto: リンクを組み立てます。
+func BuildMailto(address, subject, body string) string {
+	return fmt.Sprintf("mailto:%s?subject=%s&body=%s", address, url.QueryEscape(subject), url.QueryEscape(body))
+}
+
+// BuildGeo は geo:lat,lon 形式の文字列を組み立てます。
+func BuildGeo(lat, lon string) string {
+	return fmt.Sprintf("geo:%s,%s", lat, lon)
+}
+
+// BuildSMS は sms:番号?body=本文 形式の文字列を組み立てます。
+func BuildSMS(number, message string) string {
+	if message == "" {
+		return fmt.Sprintf("sms:%s", number)
+	}
+	return fmt.Sprintf("sms:%s?body=%s", number, url.QueryEscape(message))
+}
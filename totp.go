@@ -0,0 +1,44 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"net/url"
+)
+
+// GenerateRandomTOTPSecret はRFC 6238のTOTPシークレットとして使える、ランダムな10バイトを
+// Base32(パディングなし)でエンコードした文字列を生成します。
+func GenerateRandomTOTPSecret() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("ランダムシークレットの生成に失敗しました: %w", err)
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
+
+// BuildTOTPURI は RFC 6238 に従った otpauth://totp/... 形式のURIを組み立てます。
+// issuer と label はラベル部分 "issuer:label" として表示され、クエリにも issuer を含めます
+// (Google Authenticator等、双方を見る実装があるため)。
+func BuildTOTPURI(issuer, label, secret string, digits, period int) string {
+	displayLabel := label
+	if issuer != "" {
+		displayLabel = fmt.Sprintf("%s:%s", issuer, label)
+	}
+
+	u := url.URL{
+		Scheme: "otpauth",
+		Host:   "totp",
+		Path:   "/" + displayLabel,
+	}
+	q := url.Values{}
+	q.Set("secret", secret)
+	if issuer != "" {
+		q.Set("issuer", issuer)
+	}
+	q.Set("digits", fmt.Sprintf("%d", digits))
+	q.Set("period", fmt.Sprintf("%d", period))
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}